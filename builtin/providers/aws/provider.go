@@ -83,56 +83,58 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"aws_app_cookie_stickiness_policy": resourceAwsAppCookieStickinessPolicy(),
-			"aws_autoscaling_group":            resourceAwsAutoscalingGroup(),
-			"aws_customer_gateway":             resourceAwsCustomerGateway(),
-			"aws_db_instance":                  resourceAwsDbInstance(),
-			"aws_db_parameter_group":           resourceAwsDbParameterGroup(),
-			"aws_db_security_group":            resourceAwsDbSecurityGroup(),
-			"aws_db_subnet_group":              resourceAwsDbSubnetGroup(),
-			"aws_ebs_volume":                   resourceAwsEbsVolume(),
-			"aws_eip":                          resourceAwsEip(),
-			"aws_elasticache_cluster":          resourceAwsElasticacheCluster(),
-			"aws_elasticache_security_group":   resourceAwsElasticacheSecurityGroup(),
-			"aws_elasticache_subnet_group":     resourceAwsElasticacheSubnetGroup(),
-			"aws_elb":                          resourceAwsElb(),
-			"aws_iam_access_key":               resourceAwsIamAccessKey(),
-			"aws_iam_group_policy":             resourceAwsIamGroupPolicy(),
-			"aws_iam_group":                    resourceAwsIamGroup(),
-			"aws_iam_instance_profile":         resourceAwsIamInstanceProfile(),
-			"aws_iam_policy":                   resourceAwsIamPolicy(),
-			"aws_iam_role_policy":              resourceAwsIamRolePolicy(),
-			"aws_iam_role":                     resourceAwsIamRole(),
-			"aws_iam_user_policy":              resourceAwsIamUserPolicy(),
-			"aws_iam_user":                     resourceAwsIamUser(),
-			"aws_instance":                     resourceAwsInstance(),
-			"aws_internet_gateway":             resourceAwsInternetGateway(),
-			"aws_key_pair":                     resourceAwsKeyPair(),
-			"aws_launch_configuration":         resourceAwsLaunchConfiguration(),
-			"aws_lb_cookie_stickiness_policy":  resourceAwsLBCookieStickinessPolicy(),
-			"aws_main_route_table_association": resourceAwsMainRouteTableAssociation(),
-			"aws_network_acl":                  resourceAwsNetworkAcl(),
-			"aws_network_interface":            resourceAwsNetworkInterface(),
-			"aws_proxy_protocol_policy":        resourceAwsProxyProtocolPolicy(),
-			"aws_route53_record":               resourceAwsRoute53Record(),
-			"aws_route53_zone_association":     resourceAwsRoute53ZoneAssociation(),
-			"aws_route53_zone":                 resourceAwsRoute53Zone(),
-			"aws_route_table_association":      resourceAwsRouteTableAssociation(),
-			"aws_route_table":                  resourceAwsRouteTable(),
-			"aws_s3_bucket":                    resourceAwsS3Bucket(),
-			"aws_security_group":               resourceAwsSecurityGroup(),
-			"aws_security_group_rule":          resourceAwsSecurityGroupRule(),
-			"aws_sqs_queue":                    resourceAwsSqsQueue(),
-			"aws_sns_topic":                    resourceAwsSnsTopic(),
-			"aws_sns_topic_subscription":       resourceAwsSnsTopicSubscription(),
-			"aws_subnet":                       resourceAwsSubnet(),
-			"aws_vpc_dhcp_options_association": resourceAwsVpcDhcpOptionsAssociation(),
-			"aws_vpc_dhcp_options":             resourceAwsVpcDhcpOptions(),
-			"aws_vpc_peering_connection":       resourceAwsVpcPeeringConnection(),
-			"aws_vpc":                          resourceAwsVpc(),
-			"aws_vpn_connection":               resourceAwsVpnConnection(),
-			"aws_vpn_connection_route":         resourceAwsVpnConnectionRoute(),
-			"aws_vpn_gateway":                  resourceAwsVpnGateway(),
+			"aws_app_cookie_stickiness_policy":         resourceAwsAppCookieStickinessPolicy(),
+			"aws_autoscaling_group":                    resourceAwsAutoscalingGroup(),
+			"aws_customer_gateway":                     resourceAwsCustomerGateway(),
+			"aws_db_instance":                          resourceAwsDbInstance(),
+			"aws_db_parameter_group":                   resourceAwsDbParameterGroup(),
+			"aws_db_security_group":                    resourceAwsDbSecurityGroup(),
+			"aws_db_subnet_group":                      resourceAwsDbSubnetGroup(),
+			"aws_ebs_volume":                           resourceAwsEbsVolume(),
+			"aws_eip":                                  resourceAwsEip(),
+			"aws_elasticache_cluster":                  resourceAwsElasticacheCluster(),
+			"aws_elasticache_security_group":           resourceAwsElasticacheSecurityGroup(),
+			"aws_elasticache_subnet_group":             resourceAwsElasticacheSubnetGroup(),
+			"aws_elb":                                  resourceAwsElb(),
+			"aws_iam_access_key":                       resourceAwsIamAccessKey(),
+			"aws_iam_group_policy":                     resourceAwsIamGroupPolicy(),
+			"aws_iam_group":                            resourceAwsIamGroup(),
+			"aws_iam_instance_profile":                 resourceAwsIamInstanceProfile(),
+			"aws_iam_policy":                           resourceAwsIamPolicy(),
+			"aws_iam_role_policy":                      resourceAwsIamRolePolicy(),
+			"aws_iam_role":                             resourceAwsIamRole(),
+			"aws_iam_user_policy":                      resourceAwsIamUserPolicy(),
+			"aws_iam_user":                             resourceAwsIamUser(),
+			"aws_instance":                             resourceAwsInstance(),
+			"aws_internet_gateway":                     resourceAwsInternetGateway(),
+			"aws_key_pair":                             resourceAwsKeyPair(),
+			"aws_launch_configuration":                 resourceAwsLaunchConfiguration(),
+			"aws_lb_cookie_stickiness_policy":          resourceAwsLBCookieStickinessPolicy(),
+			"aws_main_route_table_association":         resourceAwsMainRouteTableAssociation(),
+			"aws_network_acl":                          resourceAwsNetworkAcl(),
+			"aws_network_interface":                    resourceAwsNetworkInterface(),
+			"aws_proxy_protocol_policy":                resourceAwsProxyProtocolPolicy(),
+			"aws_route53_record":                       resourceAwsRoute53Record(),
+			"aws_route53_zone_association":             resourceAwsRoute53ZoneAssociation(),
+			"aws_route53_zone":                         resourceAwsRoute53Zone(),
+			"aws_route_table_association":              resourceAwsRouteTableAssociation(),
+			"aws_route_table":                          resourceAwsRouteTable(),
+			"aws_s3_bucket":                            resourceAwsS3Bucket(),
+			"aws_security_group":                       resourceAwsSecurityGroup(),
+			"aws_security_group_rule":                  resourceAwsSecurityGroupRule(),
+			"aws_sqs_queue":                            resourceAwsSqsQueue(),
+			"aws_sns_topic":                            resourceAwsSnsTopic(),
+			"aws_sns_topic_subscription":               resourceAwsSnsTopicSubscription(),
+			"aws_subnet":                               resourceAwsSubnet(),
+			"aws_vpc_dhcp_options_association":         resourceAwsVpcDhcpOptionsAssociation(),
+			"aws_vpc_dhcp_options":                     resourceAwsVpcDhcpOptions(),
+			"aws_vpc_peering_connection":               resourceAwsVpcPeeringConnection(),
+			"aws_vpc":                                  resourceAwsVpc(),
+			"aws_vpc_endpoint":                         resourceAwsVpcEndpoint(),
+			"aws_vpc_endpoint_route_table_association": resourceAwsVpcEndpointRouteTableAssociation(),
+			"aws_vpn_connection":                       resourceAwsVpnConnection(),
+			"aws_vpn_connection_route":                 resourceAwsVpnConnectionRoute(),
+			"aws_vpn_gateway":                          resourceAwsVpnGateway(),
 		},
 
 		ConfigureFunc: providerConfigure,
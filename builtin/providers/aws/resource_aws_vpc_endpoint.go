@@ -0,0 +1,712 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/aws/awserr"
+	"github.com/awslabs/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// vpcEndpointDeleteTimeout is how long to wait for a VPC Endpoint to
+// finish deleting.
+var vpcEndpointDeleteTimeout = 10 * time.Minute
+
+func resourceAwsVpcEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVPCEndpointCreate,
+		Read:   resourceAwsVPCEndpointRead,
+		Update: resourceAwsVPCEndpointUpdate,
+		Delete: resourceAwsVPCEndpointDelete,
+
+		Schema: map[string]*schema.Schema{
+			"vpc_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"service_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_document": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				StateFunc: normalizeJson,
+			},
+
+			"validate_policy_actions": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"prevent_duplicate": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"detach_route_tables_on_destroy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"create_timeout": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "10m",
+			},
+
+			"route_tables": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"prefix_list_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cidr_blocks": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"service_short_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"creation_timestamp": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsVPCEndpointCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if err := vpcEndpointValidatePolicy(d); err != nil {
+		return err
+	}
+
+	timeout, err := time.ParseDuration(d.Get("create_timeout").(string))
+	if err != nil {
+		return fmt.Errorf("Error parsing create_timeout: %s", err)
+	}
+
+	// Without route tables the endpoint is created with no routes, and
+	// tables can be associated later.
+	routeTables := expandVpcEndpointIDs(d.Get("route_tables").(*schema.Set).List())
+	for _, w := range vpcEndpointWarnings(len(routeTables)) {
+		log.Printf("[WARN] VPC Endpoint for %s: %s", d.Get("service_name").(string), w)
+	}
+
+	if d.Get("prevent_duplicate").(bool) {
+		endpoints, err := vpcEndpointsForService(
+			conn, d.Get("vpc_id").(string), d.Get("service_name").(string))
+		if err != nil {
+			return fmt.Errorf("Error checking for duplicate VPC Endpoints: %s", err)
+		}
+		if dup := vpcEndpointDuplicate(endpoints); dup != nil {
+			return fmt.Errorf(
+				"VPC Endpoint %s already exists for %s in %s",
+				*dup.VPCEndpointID, d.Get("service_name").(string), d.Get("vpc_id").(string))
+		}
+	}
+
+	createOpts := &ec2.CreateVPCEndpointInput{
+		VPCID:       aws.String(d.Get("vpc_id").(string)),
+		ServiceName: aws.String(d.Get("service_name").(string)),
+	}
+	if len(routeTables) > 0 {
+		createOpts.RouteTableIDs = routeTables
+	}
+	if v, ok := d.GetOk("policy_document"); ok {
+		createOpts.PolicyDocument = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] VPC Endpoint create config: %#v", createOpts)
+	resp, err := conn.CreateVPCEndpoint(createOpts)
+	if err != nil {
+		err = vpcEndpointRouteConflictError(conn, err, "", d, routeTables)
+		return fmt.Errorf("Error creating VPC Endpoint: %s", err)
+	}
+
+	d.SetId(*resp.VPCEndpoint.VPCEndpointID)
+	log.Printf("[INFO] VPC Endpoint ID: %s", d.Id())
+
+	// Wait for the endpoint to become available. Any state other than
+	// pending, such as deleting or deleted, is reported as an error.
+	log.Printf(
+		"[DEBUG] Waiting for VPC Endpoint (%s) to become available",
+		d.Id())
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"pending"},
+		Target:     "available",
+		Refresh:    resourceAwsVPCEndpointStateRefreshFunc(conn, d.Id()),
+		Timeout:    timeout,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for VPC Endpoint (%s) to become available: %s",
+			d.Id(), err)
+	}
+
+	return resourceAwsVPCEndpointRead(d, meta)
+}
+
+func resourceAwsVPCEndpointRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	vpceRaw, state, err := resourceAwsVPCEndpointStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		return fmt.Errorf("Error reading VPC Endpoint (%s): %s", d.Id(), err)
+	}
+	if vpceRaw == nil || state == "deleted" {
+		log.Printf("[WARN] VPC Endpoint (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	vpce := vpceRaw.(*ec2.VPCEndpoint)
+
+	// vpc_id is ForceNew, so an endpoint reporting a different VPC than the
+	// one in state is not the endpoint we manage anymore.
+	if v := d.Get("vpc_id").(string); vpcEndpointVpcChanged(v, vpce) {
+		log.Printf(
+			"[WARN] VPC Endpoint (%s) is in VPC %s, expected %s, removing from state",
+			d.Id(), *vpce.VPCID, v)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("vpc_id", vpce.VPCID)
+	d.Set("service_name", vpce.ServiceName)
+	d.Set("service_short_name", vpcEndpointServiceShortName(*vpce.ServiceName))
+	if vpce.PolicyDocument != nil {
+		d.Set("policy_document", normalizeJson(*vpce.PolicyDocument))
+	}
+	d.Set("state", vpce.State)
+	if vpce.CreationTimestamp != nil {
+		d.Set("creation_timestamp", vpce.CreationTimestamp.Format(time.RFC3339))
+	}
+	d.Set("route_tables", flattenVpcEndpointIDs(vpce.RouteTableIDs))
+
+	// Endpoints route to the service through a managed prefix list named
+	// after the service. The lookup only feeds these computed attributes,
+	// so failing it, e.g. without ec2:DescribePrefixLists, leaves them
+	// empty instead of failing Read.
+	prefixListID := ""
+	var cidrBlocks []string
+	pl, err := vpcEndpointPrefixList(conn, *vpce.ServiceName)
+	if err != nil {
+		log.Printf("[WARN] %s, leaving prefix_list_id and cidr_blocks empty", err)
+	} else if pl != nil {
+		prefixListID = *pl.PrefixListID
+		cidrBlocks = flattenVpcEndpointCidrBlocks(pl.CIDRs)
+	}
+	d.Set("prefix_list_id", prefixListID)
+	d.Set("cidr_blocks", cidrBlocks)
+
+	return nil
+}
+
+func resourceAwsVPCEndpointUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	modifyOpts := &ec2.ModifyVPCEndpointInput{
+		VPCEndpointID: aws.String(d.Id()),
+	}
+	modify := false
+
+	if d.HasChange("route_tables") {
+		o, n := d.GetChange("route_tables")
+		add, remove := vpcEndpointIDChanges(o.(*schema.Set), n.(*schema.Set))
+		if len(add) > 0 {
+			modifyOpts.AddRouteTableIDs = add
+			modify = true
+		}
+		if len(remove) > 0 {
+			modifyOpts.RemoveRouteTableIDs = remove
+			modify = true
+		}
+	}
+
+	if d.HasChange("policy_document") {
+		if err := vpcEndpointValidatePolicy(d); err != nil {
+			return err
+		}
+
+		modifyOpts.PolicyDocument = aws.String(d.Get("policy_document").(string))
+		modify = true
+	}
+
+	if modify {
+		log.Printf("[DEBUG] VPC Endpoint modify config: %#v", modifyOpts)
+		if _, err := conn.ModifyVPCEndpoint(modifyOpts); err != nil {
+			err = vpcEndpointRouteConflictError(conn, err, d.Id(), d, modifyOpts.AddRouteTableIDs)
+			return fmt.Errorf("Error modifying VPC Endpoint (%s): %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsVPCEndpointRead(d, meta)
+}
+
+// vpcEndpointVpcChanged reports whether the described endpoint is in a
+// different VPC than the one recorded in state. An empty vpcID, as on the
+// first read after create, never counts as a change.
+func vpcEndpointVpcChanged(vpcID string, vpce *ec2.VPCEndpoint) bool {
+	return vpcID != "" && vpce.VPCID != nil && *vpce.VPCID != vpcID
+}
+
+// vpcEndpointRouteConflictError turns a RouteAlreadyExists error into one
+// naming the other endpoints that already route the service through the
+// given route tables. Any other error is returned unchanged, as is the
+// original error if the conflicting endpoints can't be looked up.
+func vpcEndpointRouteConflictError(conn *ec2.EC2, err error, id string, d *schema.ResourceData, routeTables []*string) error {
+	if !isVpcEndpointRouteAlreadyExists(err) {
+		return err
+	}
+
+	serviceName := d.Get("service_name").(string)
+	endpoints, derr := vpcEndpointsForService(conn, d.Get("vpc_id").(string), serviceName)
+	if derr != nil {
+		log.Printf("[WARN] Error looking up conflicting VPC Endpoints: %s", derr)
+		return err
+	}
+
+	conflicts := vpcEndpointRouteConflicts(endpoints, id, routeTables)
+	if len(conflicts) == 0 {
+		return err
+	}
+
+	return fmt.Errorf(
+		"route tables already have a route to %s through another VPC Endpoint: %s",
+		serviceName, strings.Join(conflicts, ", "))
+}
+
+// vpcEndpointsForService returns all endpoints in the VPC for the service.
+func vpcEndpointsForService(conn *ec2.EC2, vpcID, serviceName string) ([]*ec2.VPCEndpoint, error) {
+	resp, err := conn.DescribeVPCEndpoints(&ec2.DescribeVPCEndpointsInput{
+		Filters: []*ec2.Filter{
+			&ec2.Filter{
+				Name:   aws.String("vpc-id"),
+				Values: []*string{aws.String(vpcID)},
+			},
+			&ec2.Filter{
+				Name:   aws.String("service-name"),
+				Values: []*string{aws.String(serviceName)},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+
+	return resp.VPCEndpoints, nil
+}
+
+// vpcEndpointDuplicate returns the first of the endpoints that is not
+// being deleted, or nil if there is none.
+func vpcEndpointDuplicate(endpoints []*ec2.VPCEndpoint) *ec2.VPCEndpoint {
+	for _, vpce := range endpoints {
+		if vpce.VPCEndpointID == nil || vpcEndpointGone(vpce) {
+			continue
+		}
+		return vpce
+	}
+
+	return nil
+}
+
+// vpcEndpointGone reports whether the endpoint is deleting or deleted.
+func vpcEndpointGone(vpce *ec2.VPCEndpoint) bool {
+	return vpce.State != nil && (*vpce.State == "deleting" || *vpce.State == "deleted")
+}
+
+func isVpcEndpointRouteAlreadyExists(err error) bool {
+	ec2err, ok := err.(awserr.Error)
+	return ok && ec2err.Code() == "RouteAlreadyExists"
+}
+
+// vpcEndpointRouteConflicts returns "<route table> (<endpoint>)" for each
+// of the route tables that another live endpoint is already associated
+// with. The endpoint with the given id is skipped.
+func vpcEndpointRouteConflicts(endpoints []*ec2.VPCEndpoint, id string, routeTables []*string) []string {
+	wanted := make(map[string]bool, len(routeTables))
+	for _, rt := range routeTables {
+		wanted[*rt] = true
+	}
+
+	var conflicts []string
+	for _, vpce := range endpoints {
+		if vpce.VPCEndpointID == nil || *vpce.VPCEndpointID == id {
+			continue
+		}
+		if vpcEndpointGone(vpce) {
+			continue
+		}
+
+		for _, rt := range flattenVpcEndpointIDs(vpce.RouteTableIDs) {
+			if wanted[rt] {
+				conflicts = append(conflicts, fmt.Sprintf("%s (%s)", rt, *vpce.VPCEndpointID))
+			}
+		}
+	}
+	sort.Strings(conflicts)
+
+	return conflicts
+}
+
+// vpcEndpointPrefixList returns the managed prefix list for a gateway
+// endpoint service, or nil if the service has none.
+func vpcEndpointPrefixList(conn *ec2.EC2, serviceName string) (*ec2.PrefixList, error) {
+	resp, err := conn.DescribePrefixLists(&ec2.DescribePrefixListsInput{
+		Filters: []*ec2.Filter{
+			&ec2.Filter{
+				Name:   aws.String("prefix-list-name"),
+				Values: []*string{aws.String(serviceName)},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error reading prefix list for %s: %s", serviceName, err)
+	}
+	if resp == nil || len(resp.PrefixLists) == 0 {
+		return nil, nil
+	}
+
+	return resp.PrefixLists[0], nil
+}
+
+// vpcEndpointIDChanges returns the IDs in ns but not os, and those in os
+// but not ns.
+func vpcEndpointIDChanges(os, ns *schema.Set) ([]*string, []*string) {
+	return expandVpcEndpointIDs(ns.Difference(os).List()),
+		expandVpcEndpointIDs(os.Difference(ns).List())
+}
+
+// expandVpcEndpointIDs dedupes and sorts a list of configured IDs, so
+// requests don't depend on the order the IDs were given in.
+func expandVpcEndpointIDs(configured []interface{}) []*string {
+	ids := make([]string, 0, len(configured))
+	for _, v := range configured {
+		ids = append(ids, v.(string))
+	}
+
+	ids = uniqueSortedVpcEndpointIDs(ids)
+
+	result := make([]*string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, aws.String(id))
+	}
+	return result
+}
+
+// flattenVpcEndpointIDs dedupes and sorts a list of IDs returned by the API
+// before they are stored in state.
+func flattenVpcEndpointIDs(list []*string) []string {
+	return uniqueSortedVpcEndpointIDs(flattenStringList(list))
+}
+
+func uniqueSortedVpcEndpointIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// flattenVpcEndpointCidrBlocks sorts a prefix list's CIDR blocks, which
+// DescribePrefixLists returns in no particular order.
+func flattenVpcEndpointCidrBlocks(list []*string) []string {
+	cidrs := flattenStringList(list)
+	sort.Strings(cidrs)
+
+	return cidrs
+}
+
+// vpcEndpointServiceShortName returns the service part of an endpoint
+// service name, e.g. "s3" for "com.amazonaws.us-west-2.s3". Custom
+// PrivateLink services are identified by their vpce-svc ID instead.
+func vpcEndpointServiceShortName(serviceName string) string {
+	parts := strings.Split(serviceName, ".")
+	if last := parts[len(parts)-1]; strings.HasPrefix(last, "vpce-svc-") {
+		return last
+	}
+
+	// com.amazonaws.<region>.<service>, where the service itself may be
+	// dotted, as in "ecr.dkr".
+	if len(parts) > 3 && parts[0] == "com" && parts[1] == "amazonaws" {
+		return strings.Join(parts[3:], ".")
+	}
+
+	return parts[len(parts)-1]
+}
+
+// vpcEndpointWarnings returns warnings about configurations that are valid
+// but unlikely to be what was intended.
+func vpcEndpointWarnings(routeTables int) []string {
+	var ws []string
+	if routeTables == 0 {
+		ws = append(ws, "endpoint has no route_tables, traffic will not use it "+
+			"until route tables are associated with aws_vpc_endpoint_route_table_association")
+	}
+
+	return ws
+}
+
+// vpcEndpointValidatePolicy checks that policy_document is valid JSON and,
+// if requested, that its actions belong to the endpoint's service.
+func vpcEndpointValidatePolicy(d *schema.ResourceData) error {
+	policy := d.Get("policy_document").(string)
+	if policy == "" {
+		return nil
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return fmt.Errorf("policy_document is not valid JSON: %s", err)
+	}
+
+	return vpcEndpointValidatePolicyActions(d)
+}
+
+// vpcEndpointPolicyActionPrefixes maps the short name of services with a
+// known action namespace to the prefix every policy action must carry.
+var vpcEndpointPolicyActionPrefixes = map[string]string{
+	"s3":       "s3:",
+	"dynamodb": "dynamodb:",
+}
+
+// vpcEndpointValidatePolicyActions checks the configured policy_document
+// against the service's action namespace when validate_policy_actions is
+// set. Services without a known namespace are not checked.
+func vpcEndpointValidatePolicyActions(d *schema.ResourceData) error {
+	if !d.Get("validate_policy_actions").(bool) {
+		return nil
+	}
+
+	policy := d.Get("policy_document").(string)
+	if policy == "" {
+		return nil
+	}
+
+	service := vpcEndpointServiceShortName(d.Get("service_name").(string))
+	unknown, err := vpcEndpointPolicyUnknownActions(service, policy)
+	if err != nil {
+		return err
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf(
+			"policy_document contains actions not valid for %s: %s",
+			service, strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// vpcEndpointPolicyUnknownActions returns the actions in the policy that do
+// not belong to the given service. Wildcard actions are always allowed.
+func vpcEndpointPolicyUnknownActions(service, policy string) ([]string, error) {
+	prefix, ok := vpcEndpointPolicyActionPrefixes[service]
+	if !ok {
+		return nil, nil
+	}
+
+	var doc struct {
+		Statement interface{}
+	}
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return nil, fmt.Errorf("Error parsing policy_document: %s", err)
+	}
+
+	// Statement and Action may each be a single value or a list.
+	var statements []interface{}
+	switch v := doc.Statement.(type) {
+	case []interface{}:
+		statements = v
+	case map[string]interface{}:
+		statements = []interface{}{v}
+	}
+
+	var unknown []string
+	for _, raw := range statements {
+		stmt, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var actions []interface{}
+		switch v := stmt["Action"].(type) {
+		case []interface{}:
+			actions = v
+		case string:
+			actions = []interface{}{v}
+		}
+
+		for _, a := range actions {
+			action, ok := a.(string)
+			if !ok || action == "*" {
+				continue
+			}
+			if !strings.HasPrefix(strings.ToLower(action), prefix) {
+				unknown = append(unknown, action)
+			}
+		}
+	}
+
+	return unknown, nil
+}
+
+func resourceAwsVPCEndpointDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	// Removing the routes first lets route tables destroyed in the same
+	// apply go away without a DependencyViolation while the endpoint is
+	// still being deleted.
+	if d.Get("detach_route_tables_on_destroy").(bool) {
+		routeTables := expandVpcEndpointIDs(d.Get("route_tables").(*schema.Set).List())
+		if len(routeTables) > 0 {
+			log.Printf("[INFO] Detaching route tables from VPC Endpoint: %s", d.Id())
+			_, err := conn.ModifyVPCEndpoint(&ec2.ModifyVPCEndpointInput{
+				VPCEndpointID:       aws.String(d.Id()),
+				RemoveRouteTableIDs: routeTables,
+			})
+			if err != nil {
+				return fmt.Errorf(
+					"Error detaching route tables from VPC Endpoint (%s): %s", d.Id(), err)
+			}
+		}
+	}
+
+	log.Printf("[INFO] Deleting VPC Endpoint: %s", d.Id())
+	resp, err := conn.DeleteVPCEndpoints(&ec2.DeleteVPCEndpointsInput{
+		VPCEndpointIDs: []*string{aws.String(d.Id())},
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting VPC Endpoint (%s): %s", d.Id(), err)
+	}
+	if err := vpcEndpointDeleteError(resp.Unsuccessful); err != nil {
+		return fmt.Errorf("Error deleting VPC Endpoint (%s): %s", d.Id(), err)
+	}
+
+	return vpcEndpointWaitForDeleted(conn, d.Id())
+}
+
+// vpcEndpointDeleteError returns the first failure DeleteVPCEndpoints
+// reported for an endpoint. The call itself succeeds even when nothing was
+// deleted. An endpoint that is already gone is not a failure.
+func vpcEndpointDeleteError(unsuccessful []*ec2.UnsuccessfulItem) error {
+	for _, item := range unsuccessful {
+		if item.Error == nil {
+			continue
+		}
+
+		code := ""
+		if item.Error.Code != nil {
+			code = *item.Error.Code
+		}
+		if strings.HasSuffix(code, ".NotFound") {
+			continue
+		}
+
+		message := code
+		if item.Error.Message != nil {
+			message = fmt.Sprintf("%s: %s", code, *item.Error.Message)
+		}
+		return fmt.Errorf("%s", message)
+	}
+
+	return nil
+}
+
+// vpcEndpointWaitForDeleted waits for the endpoint to reach the deleted
+// state or to disappear from the API altogether.
+func vpcEndpointWaitForDeleted(conn *ec2.EC2, id string) error {
+	log.Printf(
+		"[DEBUG] Waiting for VPC Endpoint (%s) to be deleted",
+		id)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"available", "pending", "deleting"},
+		Target:  "deleted",
+		Refresh: func() (interface{}, string, error) {
+			vpce, state, err := resourceAwsVPCEndpointStateRefreshFunc(conn, id)()
+			if err == nil && vpce == nil {
+				// The endpoint is no longer listed at all, which is as
+				// good as deleted.
+				return id, "deleted", nil
+			}
+			return vpce, state, err
+		},
+		Timeout:    vpcEndpointDeleteTimeout,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for VPC Endpoint (%s) to be deleted: %s",
+			id, err)
+	}
+
+	return nil
+}
+
+// resourceAwsVPCEndpointStateRefreshFunc returns a resource.StateRefreshFunc
+// that is used to watch a VPC Endpoint. A nil result means the endpoint
+// does not exist.
+func resourceAwsVPCEndpointStateRefreshFunc(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeVPCEndpoints(&ec2.DescribeVPCEndpointsInput{
+			VPCEndpointIDs: []*string{aws.String(id)},
+		})
+		if err != nil {
+			if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidVpcEndpointId.NotFound" {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if resp == nil || len(resp.VPCEndpoints) == 0 {
+			return nil, "", nil
+		}
+
+		vpce := resp.VPCEndpoints[0]
+		return vpce, *vpce.State, nil
+	}
+}
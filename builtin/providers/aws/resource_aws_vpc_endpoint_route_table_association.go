@@ -0,0 +1,131 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/aws/awserr"
+	"github.com/awslabs/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsVpcEndpointRouteTableAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVpcEndpointRouteTableAssociationCreate,
+		Read:   resourceAwsVpcEndpointRouteTableAssociationRead,
+		Delete: resourceAwsVpcEndpointRouteTableAssociationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"vpc_endpoint_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"route_table_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsVpcEndpointRouteTableAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	endpointId := d.Get("vpc_endpoint_id").(string)
+	rtId := d.Get("route_table_id").(string)
+
+	vpce, err := vpcEndpointForAssociation(conn, endpointId)
+	if err != nil {
+		return err
+	}
+	if vpce == nil {
+		return fmt.Errorf("VPC Endpoint (%s) not found", endpointId)
+	}
+
+	log.Printf(
+		"[INFO] Creating VPC Endpoint route table association: %s => %s",
+		endpointId, rtId)
+	_, err = conn.ModifyVPCEndpoint(&ec2.ModifyVPCEndpointInput{
+		VPCEndpointID:    aws.String(endpointId),
+		AddRouteTableIDs: []*string{aws.String(rtId)},
+	})
+	if err != nil {
+		return fmt.Errorf(
+			"Error creating VPC Endpoint (%s) route table (%s) association: %s",
+			endpointId, rtId, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", endpointId, rtId))
+
+	return resourceAwsVpcEndpointRouteTableAssociationRead(d, meta)
+}
+
+func resourceAwsVpcEndpointRouteTableAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	endpointId := d.Get("vpc_endpoint_id").(string)
+	rtId := d.Get("route_table_id").(string)
+
+	vpce, err := vpcEndpointForAssociation(conn, endpointId)
+	if err != nil {
+		return err
+	}
+	if vpce == nil {
+		log.Printf("[WARN] VPC Endpoint (%s) not found, removing association %s from state", endpointId, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	for _, id := range vpce.RouteTableIDs {
+		if *id == rtId {
+			return nil
+		}
+	}
+
+	log.Printf("[WARN] Route table (%s) is not associated with VPC Endpoint (%s), removing from state", rtId, endpointId)
+	d.SetId("")
+	return nil
+}
+
+func resourceAwsVpcEndpointRouteTableAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	endpointId := d.Get("vpc_endpoint_id").(string)
+	rtId := d.Get("route_table_id").(string)
+
+	log.Printf(
+		"[INFO] Deleting VPC Endpoint route table association: %s => %s",
+		endpointId, rtId)
+	_, err := conn.ModifyVPCEndpoint(&ec2.ModifyVPCEndpointInput{
+		VPCEndpointID:       aws.String(endpointId),
+		RemoveRouteTableIDs: []*string{aws.String(rtId)},
+	})
+	if err != nil {
+		ec2err, ok := err.(awserr.Error)
+		if ok && ec2err.Code() == "InvalidVpcEndpointId.NotFound" {
+			// The endpoint is gone, and the association with it.
+			return nil
+		}
+
+		return fmt.Errorf(
+			"Error deleting VPC Endpoint (%s) route table (%s) association: %s",
+			endpointId, rtId, err)
+	}
+
+	return nil
+}
+
+// vpcEndpointForAssociation returns the endpoint an association refers to,
+// or nil if it no longer exists.
+func vpcEndpointForAssociation(conn *ec2.EC2, id string) (*ec2.VPCEndpoint, error) {
+	vpceRaw, state, err := resourceAwsVPCEndpointStateRefreshFunc(conn, id)()
+	if err != nil {
+		return nil, fmt.Errorf("Error reading VPC Endpoint (%s): %s", id, err)
+	}
+	if vpceRaw == nil || state == "deleted" {
+		return nil, nil
+	}
+
+	return vpceRaw.(*ec2.VPCEndpoint), nil
+}
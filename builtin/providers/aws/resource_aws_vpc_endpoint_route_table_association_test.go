@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSVpcEndpointRouteTableAssociation_basic(t *testing.T) {
+	var endpoint ec2.VPCEndpoint
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcEndpointRouteTableAssociationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcEndpointRouteTableAssociationConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointRouteTableAssociationExists(
+						"aws_vpc_endpoint_route_table_association.a", &endpoint),
+				),
+			},
+			// route_tables is Computed, so after a refresh the endpoint
+			// lists the associated table without planning to detach it.
+			resource.TestStep{
+				Config: testAccVpcEndpointRouteTableAssociationConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"aws_vpc_endpoint.s3", "route_tables.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpcEndpointRouteTableAssociationDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).ec2conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_vpc_endpoint_route_table_association" {
+			continue
+		}
+
+		resp, err := conn.DescribeVPCEndpoints(&ec2.DescribeVPCEndpointsInput{
+			VPCEndpointIDs: []*string{aws.String(rs.Primary.Attributes["vpc_endpoint_id"])},
+		})
+		if err != nil {
+			// The endpoint is gone too
+			continue
+		}
+
+		for _, vpce := range resp.VPCEndpoints {
+			for _, id := range vpce.RouteTableIDs {
+				if *id == rs.Primary.Attributes["route_table_id"] && *vpce.State != "deleted" {
+					return fmt.Errorf("VPC Endpoint route table association still exists: %s", rs.Primary.ID)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpcEndpointRouteTableAssociationExists(n string, endpoint *ec2.VPCEndpoint) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).ec2conn
+		resp, err := conn.DescribeVPCEndpoints(&ec2.DescribeVPCEndpointsInput{
+			VPCEndpointIDs: []*string{aws.String(rs.Primary.Attributes["vpc_endpoint_id"])},
+		})
+		if err != nil {
+			return err
+		}
+		if len(resp.VPCEndpoints) == 0 {
+			return fmt.Errorf("VPC Endpoint not found")
+		}
+
+		*endpoint = *resp.VPCEndpoints[0]
+
+		for _, id := range endpoint.RouteTableIDs {
+			if *id == rs.Primary.Attributes["route_table_id"] {
+				return nil
+			}
+		}
+
+		return fmt.Errorf(
+			"Route table %s is not associated with VPC Endpoint %s",
+			rs.Primary.Attributes["route_table_id"], *endpoint.VPCEndpointID)
+	}
+}
+
+const testAccVpcEndpointRouteTableAssociationConfig = `
+provider "aws" {
+	region = "us-west-2"
+}
+
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_route_table" "foo" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_vpc_endpoint" "s3" {
+	vpc_id = "${aws_vpc.foo.id}"
+	service_name = "com.amazonaws.us-west-2.s3"
+}
+
+resource "aws_vpc_endpoint_route_table_association" "a" {
+	vpc_endpoint_id = "${aws_vpc_endpoint.s3.id}"
+	route_table_id = "${aws_route_table.foo.id}"
+}
+`
@@ -0,0 +1,864 @@
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/aws/awserr"
+	"github.com/awslabs/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// testAccVpcEndpointDefaultPolicy is the full access policy AWS attaches to
+// an endpoint when none is given.
+const testAccVpcEndpointDefaultPolicy = `{"Version":"2008-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`
+
+func TestVpcEndpointServiceShortName(t *testing.T) {
+	cases := []struct {
+		ServiceName string
+		Expected    string
+	}{
+		{"com.amazonaws.us-west-2.s3", "s3"},
+		{"com.amazonaws.eu-west-1.dynamodb", "dynamodb"},
+		{"com.amazonaws.us-east-1.ec2", "ec2"},
+		{"com.amazonaws.us-east-1.ecr.dkr", "ecr.dkr"},
+		{"com.amazonaws.vpce.us-west-2.vpce-svc-0123456789abcdef0", "vpce-svc-0123456789abcdef0"},
+	}
+
+	for _, tc := range cases {
+		actual := vpcEndpointServiceShortName(tc.ServiceName)
+		if actual != tc.Expected {
+			t.Fatalf("%s: expected %q, got %q", tc.ServiceName, tc.Expected, actual)
+		}
+	}
+}
+
+func TestVpcEndpointVpcChanged(t *testing.T) {
+	cases := []struct {
+		StateVpcID string
+		VPCID      *string
+		Expected   bool
+	}{
+		{"vpc-1234", aws.String("vpc-1234"), false},
+		{"vpc-1234", aws.String("vpc-5678"), true},
+		{"", aws.String("vpc-5678"), false},
+		{"vpc-1234", nil, false},
+	}
+
+	for i, tc := range cases {
+		vpce := &ec2.VPCEndpoint{VPCID: tc.VPCID}
+		if actual := vpcEndpointVpcChanged(tc.StateVpcID, vpce); actual != tc.Expected {
+			t.Fatalf("%d: expected %t, got %t", i, tc.Expected, actual)
+		}
+	}
+}
+
+func TestVpcEndpointDeleteError(t *testing.T) {
+	cases := []struct {
+		Unsuccessful []*ec2.UnsuccessfulItem
+		Expected     string
+	}{
+		{nil, ""},
+		{
+			[]*ec2.UnsuccessfulItem{
+				&ec2.UnsuccessfulItem{
+					ResourceID: aws.String("vpce-1234"),
+					Error: &ec2.UnsuccessfulItemError{
+						Code:    aws.String("InvalidVpcEndpoint.NotFound"),
+						Message: aws.String("The VPC endpoint 'vpce-1234' does not exist"),
+					},
+				},
+			},
+			"",
+		},
+		{
+			[]*ec2.UnsuccessfulItem{
+				&ec2.UnsuccessfulItem{
+					ResourceID: aws.String("vpce-1234"),
+					Error: &ec2.UnsuccessfulItemError{
+						Code:    aws.String("OperationNotPermitted"),
+						Message: aws.String("The endpoint is in use"),
+					},
+				},
+			},
+			"OperationNotPermitted: The endpoint is in use",
+		},
+	}
+
+	for i, tc := range cases {
+		err := vpcEndpointDeleteError(tc.Unsuccessful)
+		actual := ""
+		if err != nil {
+			actual = err.Error()
+		}
+		if actual != tc.Expected {
+			t.Fatalf("%d: expected %q, got %q", i, tc.Expected, actual)
+		}
+	}
+}
+
+// testVpcEndpointAwsError is an awserr.Error with a fixed code.
+type testVpcEndpointAwsError string
+
+func (e testVpcEndpointAwsError) Error() string   { return string(e) }
+func (e testVpcEndpointAwsError) Code() string    { return string(e) }
+func (e testVpcEndpointAwsError) Message() string { return string(e) }
+
+func TestIsVpcEndpointRouteAlreadyExists(t *testing.T) {
+	cases := []struct {
+		Err      error
+		Expected bool
+	}{
+		{testVpcEndpointAwsError("RouteAlreadyExists"), true},
+		{testVpcEndpointAwsError("InvalidRouteTableId.NotFound"), false},
+		{fmt.Errorf("RouteAlreadyExists"), false},
+	}
+
+	for i, tc := range cases {
+		if actual := isVpcEndpointRouteAlreadyExists(tc.Err); actual != tc.Expected {
+			t.Fatalf("%d: expected %t, got %t", i, tc.Expected, actual)
+		}
+	}
+}
+
+func TestVpcEndpointRouteConflicts(t *testing.T) {
+	endpoints := []*ec2.VPCEndpoint{
+		&ec2.VPCEndpoint{
+			VPCEndpointID: aws.String("vpce-self"),
+			State:         aws.String("available"),
+			RouteTableIDs: []*string{aws.String("rtb-1234")},
+		},
+		&ec2.VPCEndpoint{
+			VPCEndpointID: aws.String("vpce-other"),
+			State:         aws.String("available"),
+			RouteTableIDs: []*string{aws.String("rtb-5678"), aws.String("rtb-1234")},
+		},
+		&ec2.VPCEndpoint{
+			VPCEndpointID: aws.String("vpce-gone"),
+			State:         aws.String("deleted"),
+			RouteTableIDs: []*string{aws.String("rtb-5678")},
+		},
+	}
+
+	actual := vpcEndpointRouteConflicts(endpoints, "vpce-self", []*string{
+		aws.String("rtb-5678"), aws.String("rtb-1234"), aws.String("rtb-9012"),
+	})
+	expected := []string{"rtb-1234 (vpce-other)", "rtb-5678 (vpce-other)"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+
+	if actual := vpcEndpointRouteConflicts(endpoints, "vpce-self", nil); len(actual) != 0 {
+		t.Fatalf("expected no conflicts, got %#v", actual)
+	}
+}
+
+func TestVpcEndpointDuplicate(t *testing.T) {
+	cases := []struct {
+		Endpoints []*ec2.VPCEndpoint
+		Expected  string
+	}{
+		{nil, ""},
+		{
+			[]*ec2.VPCEndpoint{
+				&ec2.VPCEndpoint{
+					VPCEndpointID: aws.String("vpce-1234"),
+					State:         aws.String("deleted"),
+				},
+				&ec2.VPCEndpoint{
+					VPCEndpointID: aws.String("vpce-5678"),
+					State:         aws.String("deleting"),
+				},
+			},
+			"",
+		},
+		{
+			[]*ec2.VPCEndpoint{
+				&ec2.VPCEndpoint{
+					VPCEndpointID: aws.String("vpce-1234"),
+					State:         aws.String("deleted"),
+				},
+				&ec2.VPCEndpoint{
+					VPCEndpointID: aws.String("vpce-5678"),
+					State:         aws.String("available"),
+				},
+			},
+			"vpce-5678",
+		},
+		{
+			[]*ec2.VPCEndpoint{
+				&ec2.VPCEndpoint{
+					VPCEndpointID: aws.String("vpce-1234"),
+					State:         aws.String("pending"),
+				},
+			},
+			"vpce-1234",
+		},
+	}
+
+	for i, tc := range cases {
+		actual := ""
+		if dup := vpcEndpointDuplicate(tc.Endpoints); dup != nil {
+			actual = *dup.VPCEndpointID
+		}
+		if actual != tc.Expected {
+			t.Fatalf("%d: expected %q, got %q", i, tc.Expected, actual)
+		}
+	}
+}
+
+func TestVpcEndpointPolicyUnknownActions(t *testing.T) {
+	cases := []struct {
+		Service  string
+		Policy   string
+		Expected []string
+	}{
+		{
+			"s3",
+			`{"Statement":[{"Effect":"Allow","Action":["s3:GetObject","S3:PutObject"],"Resource":"*"}]}`,
+			nil,
+		},
+		{
+			"s3",
+			`{"Statement":{"Effect":"Allow","Action":"*","Resource":"*"}}`,
+			nil,
+		},
+		{
+			"s3",
+			`{"Statement":[{"Effect":"Allow","Action":["s3:GetObject","dynamodb:GetItem"],"Resource":"*"}]}`,
+			[]string{"dynamodb:GetItem"},
+		},
+		{
+			"dynamodb",
+			`{"Statement":[{"Effect":"Allow","Action":"ec2:DescribeInstances","Resource":"*"}]}`,
+			[]string{"ec2:DescribeInstances"},
+		},
+		{
+			// Services without a known namespace aren't checked
+			"ec2",
+			`{"Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`,
+			nil,
+		},
+	}
+
+	for i, tc := range cases {
+		actual, err := vpcEndpointPolicyUnknownActions(tc.Service, tc.Policy)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%d: expected %#v, got %#v", i, tc.Expected, actual)
+		}
+	}
+
+	if _, err := vpcEndpointPolicyUnknownActions("s3", "{"); err == nil {
+		t.Fatal("expected error for invalid JSON")
+	}
+}
+
+func TestExpandVpcEndpointIDs(t *testing.T) {
+	cases := []struct {
+		Input    []interface{}
+		Expected []*string
+	}{
+		{
+			[]interface{}{"rtb-c", "rtb-a", "rtb-b"},
+			[]*string{aws.String("rtb-a"), aws.String("rtb-b"), aws.String("rtb-c")},
+		},
+		{
+			[]interface{}{"rtb-b", "rtb-a", "rtb-b", "", "rtb-a"},
+			[]*string{aws.String("rtb-a"), aws.String("rtb-b")},
+		},
+		{
+			[]interface{}{},
+			[]*string{},
+		},
+	}
+
+	for i, tc := range cases {
+		actual := expandVpcEndpointIDs(tc.Input)
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%d: expected %#v, got %#v", i, tc.Expected, actual)
+		}
+	}
+}
+
+func TestVpcEndpointIDChanges(t *testing.T) {
+	cases := []struct {
+		Old    []interface{}
+		New    []interface{}
+		Add    []*string
+		Remove []*string
+	}{
+		{
+			// Equal sets must not lead to a ModifyVPCEndpoint call
+			[]interface{}{"rtb-a", "rtb-b"},
+			[]interface{}{"rtb-b", "rtb-a"},
+			[]*string{},
+			[]*string{},
+		},
+		{
+			[]interface{}{"rtb-a"},
+			[]interface{}{"rtb-a", "rtb-b"},
+			[]*string{aws.String("rtb-b")},
+			[]*string{},
+		},
+		{
+			[]interface{}{"rtb-a", "rtb-b"},
+			[]interface{}{"rtb-b", "rtb-c"},
+			[]*string{aws.String("rtb-c")},
+			[]*string{aws.String("rtb-a")},
+		},
+		{
+			// All route tables removed
+			[]interface{}{"rtb-a", "rtb-b"},
+			[]interface{}{},
+			[]*string{},
+			[]*string{aws.String("rtb-a"), aws.String("rtb-b")},
+		},
+	}
+
+	for i, tc := range cases {
+		add, remove := vpcEndpointIDChanges(
+			schema.NewSet(schema.HashString, tc.Old), schema.NewSet(schema.HashString, tc.New))
+		if !reflect.DeepEqual(add, tc.Add) {
+			t.Fatalf("%d: expected add %#v, got %#v", i, tc.Add, add)
+		}
+		if !reflect.DeepEqual(remove, tc.Remove) {
+			t.Fatalf("%d: expected remove %#v, got %#v", i, tc.Remove, remove)
+		}
+	}
+}
+
+func TestFlattenVpcEndpointIDs(t *testing.T) {
+	input := []*string{aws.String("rtb-b"), aws.String("rtb-a"), aws.String("rtb-b")}
+	expected := []string{"rtb-a", "rtb-b"}
+
+	actual := flattenVpcEndpointIDs(input)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+}
+
+func TestFlattenVpcEndpointCidrBlocks(t *testing.T) {
+	input := []*string{
+		aws.String("54.231.0.0/17"),
+		aws.String("205.251.224.0/22"),
+		aws.String("52.218.128.0/17"),
+	}
+	expected := []string{"205.251.224.0/22", "52.218.128.0/17", "54.231.0.0/17"}
+
+	actual := flattenVpcEndpointCidrBlocks(input)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+}
+
+func TestVpcEndpointWarnings(t *testing.T) {
+	cases := []struct {
+		RouteTables int
+		Warnings    int
+	}{
+		{0, 1},
+		{1, 0},
+		{2, 0},
+	}
+
+	for i, tc := range cases {
+		ws := vpcEndpointWarnings(tc.RouteTables)
+		if len(ws) != tc.Warnings {
+			t.Fatalf("%d: %d route tables: expected %d warnings, got %#v",
+				i, tc.RouteTables, tc.Warnings, ws)
+		}
+	}
+}
+
+func TestVpcEndpointPolicyNormalization(t *testing.T) {
+	configured := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "ReadOnly",
+      "Effect": "Allow",
+      "Principal": "*",
+      "Action": "s3:GetObject",
+      "Resource": "*"
+    }
+  ]
+}
+`
+	// The same policy as AWS returns it, keys reordered and compacted
+	returned := `{"Statement":[{"Resource":"*","Action":"s3:GetObject","Principal":"*","Effect":"Allow","Sid":"ReadOnly"}],"Version":"2012-10-17"}`
+
+	if a, b := normalizeJson(configured), normalizeJson(returned); a != b {
+		t.Fatalf("expected normalized policies to match:\n\n%s\n\n%s", a, b)
+	}
+}
+
+func TestAccAWSVpcEndpoint_routeTables(t *testing.T) {
+	var endpoint ec2.VPCEndpoint
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcEndpointDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcEndpointConfigRouteTables,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.s3", &endpoint),
+					testAccCheckVpcEndpointRouteTableCount(&endpoint, 2),
+					resource.TestCheckResourceAttr(
+						"aws_vpc_endpoint.s3", "route_tables.#", "2"),
+					resource.TestCheckResourceAttr(
+						"aws_vpc_endpoint.s3", "service_short_name", "s3"),
+					resource.TestCheckResourceAttr(
+						"aws_vpc_endpoint.s3", "create_timeout", "10m"),
+					testAccCheckVpcEndpointCreationTimestamp("aws_vpc_endpoint.s3"),
+					testAccCheckVpcEndpointPrefixList("aws_vpc_endpoint.s3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSVpcEndpoint_noRouteTables(t *testing.T) {
+	var endpoint ec2.VPCEndpoint
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcEndpointDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcEndpointConfigNoRouteTables,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.s3", &endpoint),
+					testAccCheckVpcEndpointRouteTableCount(&endpoint, 0),
+					resource.TestCheckResourceAttr(
+						"aws_vpc_endpoint.s3", "route_tables.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSVpcEndpoint_updateRouteTables(t *testing.T) {
+	var endpoint ec2.VPCEndpoint
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcEndpointDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcEndpointConfigSingleRouteTable,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.s3", &endpoint),
+					testAccCheckVpcEndpointRouteTableCount(&endpoint, 1),
+				),
+			},
+			resource.TestStep{
+				Config: testAccVpcEndpointConfigRouteTables,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.s3", &endpoint),
+					testAccCheckVpcEndpointRouteTableCount(&endpoint, 2),
+				),
+			},
+			resource.TestStep{
+				Config: testAccVpcEndpointConfigSingleRouteTable,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.s3", &endpoint),
+					testAccCheckVpcEndpointRouteTableCount(&endpoint, 1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSVpcEndpoint_detachRouteTablesOnDestroy(t *testing.T) {
+	var endpoint ec2.VPCEndpoint
+
+	// The final destroy removes the endpoint and its route table together.
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckVpcEndpointDestroy(s); err != nil {
+				return err
+			}
+			return testAccCheckRouteTableDestroy(s)
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcEndpointConfigDetachRouteTablesOnDestroy,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.s3", &endpoint),
+					testAccCheckVpcEndpointRouteTableCount(&endpoint, 1),
+					resource.TestCheckResourceAttr(
+						"aws_vpc_endpoint.s3", "detach_route_tables_on_destroy", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSVpcEndpoint_disappears(t *testing.T) {
+	var endpoint ec2.VPCEndpoint
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcEndpointDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcEndpointConfigSingleRouteTable,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.s3", &endpoint),
+					testAccCheckVpcEndpointDisappears(&endpoint),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			resource.TestStep{
+				Config: testAccVpcEndpointConfigSingleRouteTable,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointRecreated("aws_vpc_endpoint.s3", &endpoint),
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.s3", &endpoint),
+					testAccCheckVpcEndpointRouteTableCount(&endpoint, 1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSVpcEndpoint_defaultPolicy(t *testing.T) {
+	var endpoint ec2.VPCEndpoint
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcEndpointDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcEndpointConfigSingleRouteTable,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.s3", &endpoint),
+					resource.TestCheckResourceAttr(
+						"aws_vpc_endpoint.s3", "policy_document", normalizeJson(testAccVpcEndpointDefaultPolicy)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSVpcEndpoint_updatePolicy(t *testing.T) {
+	var endpoint ec2.VPCEndpoint
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcEndpointDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcEndpointConfigRestrictivePolicy,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.s3", &endpoint),
+					testAccCheckVpcEndpointPolicyContains(&endpoint, `"Action":"s3:GetObject"`),
+				),
+			},
+			resource.TestStep{
+				Config: testAccVpcEndpointConfigOpenPolicy,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.s3", &endpoint),
+					testAccCheckVpcEndpointPolicyContains(&endpoint, `"Action":"*"`),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpcEndpointDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).ec2conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_vpc_endpoint" {
+			continue
+		}
+
+		resp, err := conn.DescribeVPCEndpoints(&ec2.DescribeVPCEndpointsInput{
+			VPCEndpointIDs: []*string{aws.String(rs.Primary.ID)},
+		})
+		if err != nil {
+			ec2err, ok := err.(awserr.Error)
+			if ok && ec2err.Code() == "InvalidVpcEndpointId.NotFound" {
+				continue
+			}
+			return err
+		}
+
+		if len(resp.VPCEndpoints) > 0 && *resp.VPCEndpoints[0].State != "deleted" {
+			return fmt.Errorf("VPC Endpoint (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpcEndpointExists(n string, endpoint *ec2.VPCEndpoint) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No VPC Endpoint ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).ec2conn
+		resp, err := conn.DescribeVPCEndpoints(&ec2.DescribeVPCEndpointsInput{
+			VPCEndpointIDs: []*string{aws.String(rs.Primary.ID)},
+		})
+		if err != nil {
+			return err
+		}
+		if len(resp.VPCEndpoints) == 0 {
+			return fmt.Errorf("VPC Endpoint not found")
+		}
+
+		*endpoint = *resp.VPCEndpoints[0]
+
+		return nil
+	}
+}
+
+// testAccCheckVpcEndpointDisappears deletes the endpoint behind
+// Terraform's back and waits for it to be gone.
+func testAccCheckVpcEndpointDisappears(endpoint *ec2.VPCEndpoint) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).ec2conn
+
+		resp, err := conn.DeleteVPCEndpoints(&ec2.DeleteVPCEndpointsInput{
+			VPCEndpointIDs: []*string{endpoint.VPCEndpointID},
+		})
+		if err != nil {
+			return err
+		}
+		if err := vpcEndpointDeleteError(resp.Unsuccessful); err != nil {
+			return err
+		}
+
+		return vpcEndpointWaitForDeleted(conn, *endpoint.VPCEndpointID)
+	}
+}
+
+// testAccCheckVpcEndpointRecreated checks that the endpoint in state is
+// not the one that was previously deleted out of band.
+func testAccCheckVpcEndpointRecreated(n string, old *ec2.VPCEndpoint) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == *old.VPCEndpointID {
+			return fmt.Errorf("VPC Endpoint (%s) was not recreated", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVpcEndpointRouteTableCount(endpoint *ec2.VPCEndpoint, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(endpoint.RouteTableIDs) != count {
+			return fmt.Errorf(
+				"Expected %d route tables, got %d: %#v",
+				count, len(endpoint.RouteTableIDs), endpoint.RouteTableIDs)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVpcEndpointPolicyContains(endpoint *ec2.VPCEndpoint, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if endpoint.PolicyDocument == nil {
+			return fmt.Errorf("VPC Endpoint has no policy")
+		}
+
+		policy := normalizeJson(*endpoint.PolicyDocument)
+		if !strings.Contains(policy, expected) {
+			return fmt.Errorf("Expected policy to contain %s, got: %s", expected, policy)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVpcEndpointCreationTimestamp(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		v := rs.Primary.Attributes["creation_timestamp"]
+		if _, err := time.Parse(time.RFC3339, v); err != nil {
+			return fmt.Errorf("Bad creation_timestamp %q: %s", v, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVpcEndpointPrefixList(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		attrs := rs.Primary.Attributes
+		if !strings.HasPrefix(attrs["prefix_list_id"], "pl-") {
+			return fmt.Errorf("Bad prefix_list_id: %q", attrs["prefix_list_id"])
+		}
+		if attrs["cidr_blocks.#"] == "" || attrs["cidr_blocks.#"] == "0" {
+			return fmt.Errorf("Expected cidr_blocks to be set")
+		}
+
+		return nil
+	}
+}
+
+const testAccVpcEndpointConfigRouteTables = `
+provider "aws" {
+	region = "us-west-2"
+}
+
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_route_table" "foo" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_route_table" "bar" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_vpc_endpoint" "s3" {
+	vpc_id = "${aws_vpc.foo.id}"
+	service_name = "com.amazonaws.us-west-2.s3"
+	route_tables = ["${aws_route_table.foo.id}", "${aws_route_table.bar.id}"]
+}
+`
+
+const testAccVpcEndpointConfigSingleRouteTable = `
+provider "aws" {
+	region = "us-west-2"
+}
+
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_route_table" "foo" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_route_table" "bar" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_vpc_endpoint" "s3" {
+	vpc_id = "${aws_vpc.foo.id}"
+	service_name = "com.amazonaws.us-west-2.s3"
+	route_tables = ["${aws_route_table.foo.id}"]
+}
+`
+
+const testAccVpcEndpointConfigRestrictivePolicy = `
+provider "aws" {
+	region = "us-west-2"
+}
+
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_route_table" "foo" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_vpc_endpoint" "s3" {
+	vpc_id = "${aws_vpc.foo.id}"
+	service_name = "com.amazonaws.us-west-2.s3"
+	route_tables = ["${aws_route_table.foo.id}"]
+	policy_document = "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Sid\":\"ReadOnly\",\"Effect\":\"Allow\",\"Principal\":\"*\",\"Action\":\"s3:GetObject\",\"Resource\":\"*\"}]}"
+}
+`
+
+const testAccVpcEndpointConfigOpenPolicy = `
+provider "aws" {
+	region = "us-west-2"
+}
+
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_route_table" "foo" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_vpc_endpoint" "s3" {
+	vpc_id = "${aws_vpc.foo.id}"
+	service_name = "com.amazonaws.us-west-2.s3"
+	route_tables = ["${aws_route_table.foo.id}"]
+	policy_document = "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Sid\":\"AllowAll\",\"Effect\":\"Allow\",\"Principal\":\"*\",\"Action\":\"*\",\"Resource\":\"*\"}]}"
+}
+`
+
+const testAccVpcEndpointConfigNoRouteTables = `
+provider "aws" {
+	region = "us-west-2"
+}
+
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_vpc_endpoint" "s3" {
+	vpc_id = "${aws_vpc.foo.id}"
+	service_name = "com.amazonaws.us-west-2.s3"
+}
+`
+
+const testAccVpcEndpointConfigDetachRouteTablesOnDestroy = `
+provider "aws" {
+	region = "us-west-2"
+}
+
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_route_table" "foo" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_vpc_endpoint" "s3" {
+	vpc_id = "${aws_vpc.foo.id}"
+	service_name = "com.amazonaws.us-west-2.s3"
+	route_tables = ["${aws_route_table.foo.id}"]
+	detach_route_tables_on_destroy = true
+}
+`
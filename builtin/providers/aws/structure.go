@@ -306,3 +306,15 @@ func expandResourceRecords(recs []interface{}, typeStr string) []*route53.Resour
 	}
 	return records
 }
+
+// Takes a list of string pointers from the AWS API and returns a []string,
+// skipping nil entries
+func flattenStringList(list []*string) []string {
+	vs := make([]string, 0, len(list))
+	for _, v := range list {
+		if v != nil {
+			vs = append(vs, *v)
+		}
+	}
+	return vs
+}
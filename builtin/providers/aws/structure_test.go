@@ -557,3 +557,21 @@ func TestFlattenResourceRecords(t *testing.T) {
 		t.Fatal("expected result to have value, but got nil")
 	}
 }
+
+func TestFlattenStringList(t *testing.T) {
+	expanded := []*string{
+		aws.String("rtb-1234"),
+		nil,
+		aws.String("rtb-5678"),
+	}
+
+	result := flattenStringList(expanded)
+	expected := []string{"rtb-1234", "rtb-5678"}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf(
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			result,
+			expected)
+	}
+}
@@ -75,6 +75,11 @@ type TestStep struct {
 
 	// Destroy will create a destroy plan if set to true.
 	Destroy bool
+
+	// ExpectNonEmptyPlan can be set to true for specific types of tests
+	// that are looking to verify that a diff occurs, such as a resource
+	// being removed out-of-band during Check.
+	ExpectNonEmptyPlan bool
 }
 
 // Test performs an acceptance test on a resource.
@@ -248,7 +253,7 @@ func testStep(
 	if p, err := ctx.Plan(); err != nil {
 		return state, fmt.Errorf("Error on follow-up plan: %s", err)
 	} else {
-		if p.Diff != nil && !p.Diff.Empty() {
+		if p.Diff != nil && !p.Diff.Empty() && !step.ExpectNonEmptyPlan {
 			return state, fmt.Errorf(
 				"After applying this step, the plan was not empty:\n\n%s", p)
 		}
@@ -263,7 +268,7 @@ func testStep(
 	if p, err := ctx.Plan(); err != nil {
 		return state, fmt.Errorf("Error on second follow-up plan: %s", err)
 	} else {
-		if p.Diff != nil && !p.Diff.Empty() {
+		if p.Diff != nil && !p.Diff.Empty() && !step.ExpectNonEmptyPlan {
 			return state, fmt.Errorf(
 				"After applying this step and refreshing, the plan was not empty:\n\n%s", p)
 		}
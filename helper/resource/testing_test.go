@@ -175,6 +175,30 @@ func TestTest_stepError(t *testing.T) {
 	}
 }
 
+func TestTest_expectNonEmptyPlan(t *testing.T) {
+	mp := testProvider()
+	mp.ApplyReturn = &terraform.InstanceState{
+		ID: "foo",
+	}
+
+	mt := new(mockT)
+	Test(mt, TestCase{
+		Providers: map[string]terraform.ResourceProvider{
+			"test": mp,
+		},
+		Steps: []TestStep{
+			TestStep{
+				Config:             testConfigStr,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+
+	if mt.failed() {
+		t.Fatalf("test failed: %s", mt.failMessage())
+	}
+}
+
 func TestComposeTestCheckFunc(t *testing.T) {
 	cases := []struct {
 		F      []TestCheckFunc